@@ -0,0 +1,382 @@
+package seekstream
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+)
+
+// Backend is the storage a File writes to and reads from. It is satisfied by *os.File, which NewDiskBackend wraps
+// to add the Mover and Remover capabilities.
+type Backend interface {
+	io.ReaderAt
+	io.WriterAt
+	io.Reader
+	io.Seeker
+	io.Closer
+	Name() string
+	Truncate(size int64) error
+}
+
+// Mover is an optional capability a Backend may implement to support File.Move. Backends that have no meaningful
+// notion of a path, such as MemoryBackend, should leave it unimplemented so File.Move reports errors.ErrUnsupported.
+type Mover interface {
+	Move(path string) error
+}
+
+// Remover is an optional capability a Backend may implement to support File.Remove. Backends that have no
+// underlying storage to delete, such as MemoryBackend, should leave it unimplemented so File.Remove reports
+// errors.ErrUnsupported.
+type Remover interface {
+	Remove() error
+}
+
+// diskBackend is the default Backend, backed by a temporary file on disk.
+type diskBackend struct {
+	*os.File
+}
+
+// NewDiskBackend creates a new temporary file in the (optionally provided) directory and returns a Backend wrapping
+// it. This is the Backend NewFile used before Backend was pluggable, and remains the default for large streams.
+func NewDiskBackend(tempDir ...string) (Backend, error) {
+	dir := os.TempDir()
+	if len(tempDir) > 0 {
+		dir = tempDir[0]
+	}
+
+	file, err := ioutil.TempFile(dir, "stream_")
+	if err != nil {
+		return nil, err
+	}
+
+	return diskBackend{file}, nil
+}
+
+// Move renames the backing temporary file to path. The caller is expected to have already closed the Backend.
+func (d diskBackend) Move(path string) error {
+	return os.Rename(d.Name(), path)
+}
+
+// Remove deletes the backing temporary file. The caller is expected to have already closed the Backend.
+func (d diskBackend) Remove() error {
+	return os.Remove(d.Name())
+}
+
+// memoryChunkSize is the size of each chunk a MemoryBackend allocates, so a growing stream never needs to
+// reallocate and copy one giant contiguous buffer.
+const memoryChunkSize = 1 << 20
+
+// MemoryBackend is a Backend that keeps its data in memory as a list of fixed-size chunks. It's intended for short
+// or small streams; see SpillBackend for a backend that only holds data in memory up to a size threshold.
+type MemoryBackend struct {
+	mu     sync.Mutex
+	name   string
+	chunks [][]byte
+	size   int64
+	pos    int64
+}
+
+// NewMemoryBackend creates an empty in-memory Backend. name has no effect besides being returned by Name.
+func NewMemoryBackend(name string) *MemoryBackend {
+	return &MemoryBackend{name: name}
+}
+
+func (m *MemoryBackend) growLocked(size int64) {
+	for int64(len(m.chunks))*memoryChunkSize < size {
+		m.chunks = append(m.chunks, make([]byte, memoryChunkSize))
+	}
+}
+
+// WriteAt implements Backend.
+func (m *MemoryBackend) WriteAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, &os.PathError{Op: "writeat", Path: m.name, Err: errors.New("negative offset")}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.growLocked(off + int64(len(p)))
+
+	n := 0
+	for n < len(p) {
+		cur := off + int64(n)
+		chunk := m.chunks[cur/memoryChunkSize]
+		n += copy(chunk[cur%memoryChunkSize:], p[n:])
+	}
+
+	if end := off + int64(n); end > m.size {
+		m.size = end
+	}
+
+	return n, nil
+}
+
+// copyAtLocked copies as many of the committed bytes starting at off into p as are available, assuming m.mu is
+// already held.
+func (m *MemoryBackend) copyAtLocked(p []byte, off int64) int {
+	end := off + int64(len(p))
+	if end > m.size {
+		end = m.size
+	}
+
+	n := 0
+	for off+int64(n) < end {
+		cur := off + int64(n)
+		chunk := m.chunks[cur/memoryChunkSize]
+		n += copy(p[n:int64(n)+(end-cur)], chunk[cur%memoryChunkSize:])
+	}
+
+	return n
+}
+
+// ReadAt implements Backend. Like os.File.ReadAt, it only reports io.EOF when it returns fewer bytes than
+// requested.
+func (m *MemoryBackend) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, &os.PathError{Op: "readat", Path: m.name, Err: errors.New("negative offset")}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if off >= m.size {
+		return 0, io.EOF
+	}
+
+	n := m.copyAtLocked(p, off)
+
+	var err error
+	if n < len(p) {
+		err = io.EOF
+	}
+
+	return n, err
+}
+
+// Read implements Backend, reading sequentially from an internal cursor advanced by Read and Seek. Like
+// bytes.Reader, it only reports io.EOF once the cursor has reached the end.
+func (m *MemoryBackend) Read(p []byte) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.pos >= m.size {
+		return 0, io.EOF
+	}
+
+	n := m.copyAtLocked(p, m.pos)
+	m.pos += int64(n)
+
+	return n, nil
+}
+
+// Seek implements Backend.
+func (m *MemoryBackend) Seek(offset int64, whence int) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += m.pos
+	case io.SeekEnd:
+		offset += m.size
+	default:
+		return 0, &os.PathError{Op: "seek", Path: m.name, Err: errors.New("invalid whence")}
+	}
+
+	if offset < 0 {
+		return 0, &os.PathError{Op: "seek", Path: m.name, Err: errors.New("negative position")}
+	}
+
+	m.pos = offset
+
+	return offset, nil
+}
+
+// Close implements Backend. It is a no-op, since MemoryBackend holds no external resources.
+func (m *MemoryBackend) Close() error {
+	return nil
+}
+
+// Name implements Backend.
+func (m *MemoryBackend) Name() string {
+	return m.name
+}
+
+// Truncate implements Backend.
+func (m *MemoryBackend) Truncate(size int64) error {
+	if size < 0 {
+		return &os.PathError{Op: "truncate", Path: m.name, Err: errors.New("negative size")}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.growLocked(size)
+	m.size = size
+
+	return nil
+}
+
+// copyTo writes all of m's committed data into dst, sequentially from offset 0.
+func (m *MemoryBackend) copyTo(dst Backend) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var off int64
+	for remaining := m.size; remaining > 0; {
+		chunk := m.chunks[off/memoryChunkSize]
+		n := int64(len(chunk))
+		if n > remaining {
+			n = remaining
+		}
+
+		if _, err := dst.WriteAt(chunk[:n], off); err != nil {
+			return err
+		}
+
+		off += n
+		remaining -= n
+	}
+
+	return nil
+}
+
+// SpillBackend is a Backend that starts out as a MemoryBackend and transparently migrates its data to a
+// NewDiskBackend once the stream crosses threshold bytes. Small streams never touch disk; large ones end up
+// behaving exactly like NewDiskBackend.
+type SpillBackend struct {
+	mu        sync.Mutex
+	threshold int64
+	tempDir   []string
+	current   Backend
+	spilled   bool
+}
+
+// NewSpillBackend creates a Backend that stays in memory until a write would make the stream reach threshold
+// bytes, at which point it spills its data to a new temporary file in the (optionally provided) directory.
+func NewSpillBackend(threshold int64, tempDir ...string) *SpillBackend {
+	return &SpillBackend{
+		threshold: threshold,
+		tempDir:   tempDir,
+		current:   NewMemoryBackend(""),
+	}
+}
+
+func (s *SpillBackend) spillLocked() error {
+	mem := s.current.(*MemoryBackend)
+
+	pos, err := mem.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+
+	disk, err := NewDiskBackend(s.tempDir...)
+	if err != nil {
+		return err
+	}
+
+	if err = mem.copyTo(disk); err != nil {
+		return err
+	}
+
+	if _, err = disk.Seek(pos, io.SeekStart); err != nil {
+		return err
+	}
+
+	s.current, s.spilled = disk, true
+
+	return nil
+}
+
+// WriteAt implements Backend.
+func (s *SpillBackend) WriteAt(p []byte, off int64) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.spilled && off+int64(len(p)) >= s.threshold {
+		if err := s.spillLocked(); err != nil {
+			return 0, err
+		}
+	}
+
+	return s.current.WriteAt(p, off)
+}
+
+// ReadAt implements Backend.
+func (s *SpillBackend) ReadAt(p []byte, off int64) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.current.ReadAt(p, off)
+}
+
+// Read implements Backend.
+func (s *SpillBackend) Read(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.current.Read(p)
+}
+
+// Seek implements Backend.
+func (s *SpillBackend) Seek(offset int64, whence int) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.current.Seek(offset, whence)
+}
+
+// Close implements Backend.
+func (s *SpillBackend) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.current.Close()
+}
+
+// Name implements Backend. Before spilling it returns "", since there is no path to report yet.
+func (s *SpillBackend) Name() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.current.Name()
+}
+
+// Truncate implements Backend.
+func (s *SpillBackend) Truncate(size int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.current.Truncate(size)
+}
+
+// Move implements Mover once the stream has spilled to disk; before that, File.Move reports errors.ErrUnsupported.
+func (s *SpillBackend) Move(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, ok := s.current.(Mover)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+
+	return m.Move(path)
+}
+
+// Remove implements Remover once the stream has spilled to disk; before that, File.Remove reports
+// errors.ErrUnsupported.
+func (s *SpillBackend) Remove() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.current.(Remover)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+
+	return r.Remove()
+}
@@ -0,0 +1,234 @@
+package seekstream
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestMemoryBackend_WriteReadAt(t *testing.T) {
+	const size = memoryChunkSize*2 + 1<<10
+
+	want := make([]byte, size)
+	if _, err := rand.Read(want); err != nil {
+		t.Fatalf("rand.Read() error = %v", err)
+	}
+
+	m := NewMemoryBackend("mem")
+
+	if n, err := m.WriteAt(want, 0); err != nil || n != size {
+		t.Fatalf("MemoryBackend.WriteAt() = (%v, %v), want (%v, %v)", n, err, size, error(nil))
+	}
+
+	got := make([]byte, size)
+	n, err := m.ReadAt(got, 0)
+	if err != nil || n != size {
+		t.Fatalf("MemoryBackend.ReadAt() = (%v, %v), want (%v, %v)", n, err, size, error(nil))
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("MemoryBackend.ReadAt() != data written")
+	}
+
+	if _, err = m.ReadAt(got, -1); err == nil {
+		t.Errorf("MemoryBackend.ReadAt() error = %v, want non-nil", err)
+	}
+
+	if m.Name() != "mem" {
+		t.Errorf("MemoryBackend.Name() = %v, want %v", m.Name(), "mem")
+	}
+
+	if err = m.Close(); err != nil {
+		t.Errorf("MemoryBackend.Close() error = %v", err)
+	}
+}
+
+func TestMemoryBackend_ReadSeek(t *testing.T) {
+	m := NewMemoryBackend("")
+	if _, err := m.WriteAt([]byte("hello world"), 0); err != nil {
+		t.Fatalf("MemoryBackend.WriteAt() error = %v", err)
+	}
+
+	if n, err := m.Seek(6, io.SeekStart); err != nil || n != 6 {
+		t.Fatalf("MemoryBackend.Seek() = (%v, %v), want (%v, %v)", n, err, 6, error(nil))
+	}
+
+	got := make([]byte, 5)
+	if n, err := m.Read(got); err != nil || n != 5 {
+		t.Fatalf("MemoryBackend.Read() = (%v, %v), want (%v, %v)", n, err, 5, error(nil))
+	}
+
+	if string(got) != "world" {
+		t.Errorf("MemoryBackend.Read() = %q, want %q", got, "world")
+	}
+
+	if _, err := m.Seek(0, -1); err == nil {
+		t.Errorf("MemoryBackend.Seek() error = %v, want non-nil", err)
+	}
+}
+
+func TestMemoryBackend_Truncate(t *testing.T) {
+	m := NewMemoryBackend("")
+	if err := m.Truncate(10); err != nil {
+		t.Fatalf("MemoryBackend.Truncate() error = %v", err)
+	}
+
+	if m.size != 10 {
+		t.Errorf("MemoryBackend.size = %v, want %v", m.size, 10)
+	}
+
+	if err := m.Truncate(-1); err == nil {
+		t.Errorf("MemoryBackend.Truncate() error = %v, want non-nil", err)
+	}
+}
+
+func TestMemoryBackend_NotMoverRemover(t *testing.T) {
+	var backend Backend = NewMemoryBackend("")
+
+	if _, ok := backend.(Mover); ok {
+		t.Errorf("MemoryBackend implements Mover, want it not to")
+	}
+
+	if _, ok := backend.(Remover); ok {
+		t.Errorf("MemoryBackend implements Remover, want it not to")
+	}
+}
+
+func TestSpillBackend_Spills(t *testing.T) {
+	const threshold = 1 << 10
+
+	s := NewSpillBackend(threshold)
+	f := NewFile(s)
+	defer func() {
+		if err := f.Remove(); err != nil {
+			t.Errorf("File.Remove() error = %v", err)
+		}
+	}()
+
+	small := make([]byte, threshold/2)
+	if _, err := f.Write(small); err != nil {
+		t.Fatalf("File.Write() error = %v", err)
+	}
+
+	if s.spilled {
+		t.Errorf("SpillBackend.spilled = %v, want %v", s.spilled, false)
+	}
+
+	big := make([]byte, threshold)
+	if _, err := rand.Read(big); err != nil {
+		t.Fatalf("rand.Read() error = %v", err)
+	}
+
+	if _, err := f.Write(big); err != nil {
+		t.Fatalf("File.Write() error = %v", err)
+	}
+
+	if !s.spilled {
+		t.Errorf("SpillBackend.spilled = %v, want %v", s.spilled, true)
+	}
+
+	f.DoneWriting()
+
+	got := make([]byte, len(small)+len(big))
+	if _, err := f.ReadAt(got, 0); err != nil {
+		t.Fatalf("File.ReadAt() error = %v, want %v", err, error(nil))
+	}
+
+	if !bytes.Equal(got, append(small, big...)) {
+		t.Errorf("File.ReadAt() != data written")
+	}
+
+	if s.Name() == "" {
+		t.Errorf("SpillBackend.Name() = %q, want non-empty", s.Name())
+	}
+}
+
+func TestSpillBackend_SpillsMidSequentialRead(t *testing.T) {
+	const threshold = 1 << 10
+
+	s := NewSpillBackend(threshold)
+	f := NewFile(s)
+	defer func() {
+		if err := f.Remove(); err != nil {
+			t.Errorf("File.Remove() error = %v", err)
+		}
+	}()
+
+	small := []byte("hello world")
+	if _, err := f.Write(small); err != nil {
+		t.Fatalf("File.Write() error = %v", err)
+	}
+
+	got := make([]byte, 5)
+	if n, err := f.Read(got); err != nil || n != 5 {
+		t.Fatalf("File.Read() = (%v, %v), want (%v, %v)", n, err, 5, error(nil))
+	}
+
+	if !bytes.Equal(got, small[:5]) {
+		t.Errorf("File.Read() = %q, want %q", got, small[:5])
+	}
+
+	big := make([]byte, threshold)
+	if _, err := rand.Read(big); err != nil {
+		t.Fatalf("rand.Read() error = %v", err)
+	}
+
+	if _, err := f.Write(big); err != nil {
+		t.Fatalf("File.Write() error = %v", err)
+	}
+
+	if !s.spilled {
+		t.Errorf("SpillBackend.spilled = %v, want %v", s.spilled, true)
+	}
+
+	f.DoneWriting()
+
+	rest := make([]byte, len(small)-5+len(big))
+	if n, err := f.Read(rest); err != nil || n != len(rest) {
+		t.Fatalf("File.Read() = (%v, %v), want (%v, %v)", n, err, len(rest), error(nil))
+	}
+
+	if !bytes.Equal(rest, append(append([]byte{}, small[5:]...), big...)) {
+		t.Errorf("File.Read() after spill = data not contiguous with pre-spill read")
+	}
+}
+
+func TestSpillBackend_NeverSpills(t *testing.T) {
+	s := NewSpillBackend(1 << 20)
+	f := NewFile(s)
+
+	if _, err := f.Write([]byte("small")); err != nil {
+		t.Fatalf("File.Write() error = %v", err)
+	}
+	f.DoneWriting()
+
+	if err := f.Remove(); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("File.Remove() error = %v, want %v", err, errors.ErrUnsupported)
+	}
+}
+
+func TestDiskBackend_MoverRemover(t *testing.T) {
+	backend, err := NewDiskBackend()
+	if err != nil {
+		t.Fatalf("NewDiskBackend() error = %v", err)
+	}
+
+	if _, ok := backend.(Mover); !ok {
+		t.Errorf("diskBackend does not implement Mover, want it to")
+	}
+
+	if _, ok := backend.(Remover); !ok {
+		t.Errorf("diskBackend does not implement Remover, want it to")
+	}
+
+	if err = backend.Close(); err != nil {
+		t.Errorf("Backend.Close() error = %v", err)
+	}
+
+	if err = os.Remove(backend.Name()); err != nil {
+		t.Errorf("os.Remove() error = %v", err)
+	}
+}
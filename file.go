@@ -3,10 +3,14 @@
 package seekstream
 
 import (
+	"context"
+	"errors"
+	"hash"
 	"io"
-	"io/ioutil"
 	"os"
+	"sync"
 	"sync/atomic"
+	"time"
 )
 
 // bufSize is the default buffer size of io.copyBuffer.
@@ -14,28 +18,26 @@ const bufSize = 1 << 15
 
 // File is the seekable streaming data in the form of a file. Initialised using NewFile.
 type File struct {
-	file       *os.File
+	backend    Backend
 	r, w       int64
 	done, wait chan struct{}
-}
 
-// NewFile creates a new temporary file in the (optionally provided) directory and returns an empty File.
-func NewFile(tempDir ...string) (*File, error) {
-	TempDir := os.TempDir()
-	if len(tempDir) > 0 {
-		TempDir = tempDir[0]
-	}
+	mu            sync.Mutex
+	deadlineCh    chan struct{}
+	deadlineTimer *time.Timer
 
-	file, err := ioutil.TempFile(TempDir, "stream_")
-	if err != nil {
-		return nil, err
-	}
+	hash   hash.Hash
+	hashMu sync.Mutex
+}
 
+// NewFile creates an empty File backed by backend. Use NewDiskBackend for the traditional temp-file behavior, or
+// NewMemoryBackend/NewSpillBackend to keep (some or all of) the stream in memory.
+func NewFile(backend Backend) *File {
 	return &File{
-		file: file,
-		done: make(chan struct{}),
-		wait: make(chan struct{}),
-	}, nil
+		backend: backend,
+		done:    make(chan struct{}),
+		wait:    make(chan struct{}),
+	}
 }
 
 func (f *File) notify() {
@@ -49,13 +51,21 @@ func (f *File) notify() {
 	}
 }
 
-// Write implements the io.Writer interface and notifies all the blocked readers after each write.
+// Write implements the io.Writer interface and notifies all the blocked readers after each write. If the File was
+// created with NewFileWithHash, the written bytes are also fed into the hash.
 func (f *File) Write(p []byte) (int, error) {
 	if f.IsDone() {
 		return 0, &os.PathError{Op: "write", Path: f.Name(), Err: os.ErrClosed}
 	}
 
-	n, err := f.file.WriteAt(p, f.w)
+	n, err := f.backend.WriteAt(p, f.w)
+
+	if n > 0 && f.hash != nil {
+		f.hashMu.Lock()
+		f.hash.Write(p[:n])
+		f.hashMu.Unlock()
+	}
+
 	atomic.AddInt64(&f.w, int64(n))
 
 	f.notify()
@@ -63,40 +73,61 @@ func (f *File) Write(p []byte) (int, error) {
 	return n, err
 }
 
-// Read implements the io.Reader interface and only blocks in the case of a (0, nil) Read.
+// Read implements the io.Reader interface and only blocks in the case of a (0, nil) Read. A pending block can
+// expire due to a deadline set with SetDeadline, but is never canceled by a context; use ReadContext for that.
 func (f *File) Read(p []byte) (int, error) {
-	if !f.block(f.r) {
+	return f.ReadContext(context.Background(), p)
+}
+
+// ReadContext is Read with a ctx that can cancel a pending block, and a deadline set with SetDeadline that can
+// expire it. Either case returns ctx.Err() or os.ErrDeadlineExceeded wrapped in an *os.PathError.
+func (f *File) ReadContext(ctx context.Context, p []byte) (int, error) {
+	ok, err := f.block(ctx, f.r)
+	if err != nil {
+		return 0, &os.PathError{Op: "read", Path: f.Name(), Err: err}
+	}
+	if !ok {
 		return 0, io.EOF
 	}
 
 	p = subSlice(p, int(atomic.LoadInt64(&f.w)-f.r))
 
-	n, err := f.file.Read(p)
+	n, err := f.backend.Read(p)
 	f.r += int64(n)
 	return n, err
 }
 
-// ReadAt implements the ReaderAt interface, blocking until the buffer is filled or the EOF reached.
+// ReadAt implements the ReaderAt interface, blocking until the buffer is filled or the EOF reached. A pending block
+// can expire due to a deadline set with SetDeadline, but is never canceled by a context; use ReadAtContext for that.
 func (f *File) ReadAt(p []byte, off int64) (int, error) {
-	if !f.block(off) {
+	return f.ReadAtContext(context.Background(), p, off)
+}
+
+// ReadAtContext is ReadAt with a ctx that can cancel a pending block, and a deadline set with SetDeadline that can
+// expire it. Either case returns ctx.Err() or os.ErrDeadlineExceeded wrapped in an *os.PathError.
+func (f *File) ReadAtContext(ctx context.Context, p []byte, off int64) (int, error) {
+	ok, err := f.block(ctx, off)
+	if err != nil {
+		return 0, &os.PathError{Op: "readat", Path: f.Name(), Err: err}
+	}
+	if !ok {
 		return 0, io.EOF
 	}
 
-	var (
-		err  error
-		pLen = len(p)
-	)
+	pLen := len(p)
 
 	for {
 		n := 0
-		n, err = f.file.ReadAt(subSlice(p, int(atomic.LoadInt64(&f.w)-off)), off)
+		n, err = f.backend.ReadAt(subSlice(p, int(atomic.LoadInt64(&f.w)-off)), off)
 		p = p[n:]
 		off += int64(n)
 		if err != nil || len(p) == 0 {
 			break
 		}
 
-		if !f.block(off) {
+		if ok, err = f.block(ctx, off); err != nil {
+			return pLen - len(p), &os.PathError{Op: "readat", Path: f.Name(), Err: err}
+		} else if !ok {
 			err = io.EOF
 			break
 		}
@@ -105,31 +136,93 @@ func (f *File) ReadAt(p []byte, off int64) (int, error) {
 	return pLen - len(p), err
 }
 
-func (f *File) block(off int64) bool {
+// block waits until off < f.w, returning (true, nil). It returns (false, nil) once the File is done writing with
+// off still past the end. It returns (false, err) if ctx is canceled or the deadline set with SetDeadline passes
+// first.
+func (f *File) block(ctx context.Context, off int64) (bool, error) {
 	for off >= atomic.LoadInt64(&f.w) {
 		select {
 		case <-f.done:
-			return off < f.w
+			return off < f.w, nil
+
+		case <-ctx.Done():
+			return false, ctx.Err()
+
+		case <-f.deadlineChan():
+			return false, os.ErrDeadlineExceeded
 
 		case f.wait <- struct{}{}:
 		}
 	}
 
-	return true
+	return true, nil
 }
 
-// Seek implements the io.Seeker interface, blocking until file size is known if the whence is io.SeekEnd.
+// Seek implements the io.Seeker interface, blocking until file size is known if the whence is io.SeekEnd. That wait
+// can expire due to a deadline set with SetDeadline, but is never canceled by a context; use SeekContext for that.
 func (f *File) Seek(off int64, whence int) (int64, error) {
-	var err error
+	return f.SeekContext(context.Background(), off, whence)
+}
 
+// SeekContext is Seek with a ctx that can cancel a pending wait for io.SeekEnd, and a deadline set with
+// SetDeadline that can expire it. Either case returns ctx.Err() or os.ErrDeadlineExceeded wrapped in an
+// *os.PathError.
+func (f *File) SeekContext(ctx context.Context, off int64, whence int) (int64, error) {
 	if whence == io.SeekEnd {
-		f.Wait()
+		select {
+		case <-f.done:
+
+		case <-ctx.Done():
+			return f.r, &os.PathError{Op: "seek", Path: f.Name(), Err: ctx.Err()}
+
+		case <-f.deadlineChan():
+			return f.r, &os.PathError{Op: "seek", Path: f.Name(), Err: os.ErrDeadlineExceeded}
+		}
 	}
 
-	f.r, err = f.file.Seek(off, whence)
+	var err error
+	f.r, err = f.backend.Seek(off, whence)
 	return f.r, err
 }
 
+// deadlineChan returns the channel that SetDeadline closes once its deadline passes, or nil if no deadline is set.
+func (f *File) deadlineChan() <-chan struct{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.deadlineCh
+}
+
+// SetDeadline sets the deadline for future Read, ReadAt, and Seek calls (and their *Context variants), mirroring
+// the net.Conn deadline model. Once passed, a call that has to wait for more data (or, for Seek with io.SeekEnd,
+// for the final size) returns os.ErrDeadlineExceeded instead, until SetDeadline is called again; a call that can be
+// served from data already written does not check the deadline. A zero Time disables the deadline. Unlike the plain net.Conn model, it also wakes any call already
+// blocked in block, so a watchdog goroutine can abort an in-flight Read/ReadAt/Seek without having threaded a
+// context through the original call site.
+func (f *File) SetDeadline(t time.Time) {
+	f.mu.Lock()
+
+	if f.deadlineTimer != nil {
+		f.deadlineTimer.Stop()
+		f.deadlineTimer = nil
+	}
+
+	ch := make(chan struct{})
+	f.deadlineCh = ch
+
+	if !t.IsZero() {
+		if d := time.Until(t); d <= 0 {
+			close(ch)
+		} else {
+			f.deadlineTimer = time.AfterFunc(d, func() { close(ch) })
+		}
+	}
+
+	f.mu.Unlock()
+
+	f.notify()
+}
+
 // DoneWriting closes the File for writing.
 func (f *File) DoneWriting() {
 	select {
@@ -191,9 +284,9 @@ func (f *File) IsDone() bool {
 	}
 }
 
-// Name returns the temporary file's path.
+// Name returns the backend's name, typically the temporary file's path.
 func (f *File) Name() string {
-	return f.file.Name()
+	return f.backend.Name()
 }
 
 // Size wait for the File to be closed for writing, and returns the final file size.
@@ -202,14 +295,21 @@ func (f *File) Size() int64 {
 	return f.w
 }
 
-// Close closes the temporary file.
+// Written returns the number of bytes committed so far, without blocking. Unlike Size, it may grow on subsequent
+// calls until DoneWriting is called.
+func (f *File) Written() int64 {
+	return atomic.LoadInt64(&f.w)
+}
+
+// Close closes the backend.
 func (f *File) Close() error {
 	f.DoneWriting()
 
-	return f.file.Close()
+	return f.backend.Close()
 }
 
-// Move moves the temporary file to the new path.
+// Move moves the backend to the new path, if it implements Mover (as NewDiskBackend does). Backends with no
+// meaningful notion of a path, such as MemoryBackend, report errors.ErrUnsupported.
 func (f *File) Move(path string) error {
 	if err := f.Close(); err != nil {
 		if pErr, ok := err.(*os.PathError); !ok || pErr.Err != os.ErrClosed {
@@ -217,10 +317,16 @@ func (f *File) Move(path string) error {
 		}
 	}
 
-	return os.Rename(f.file.Name(), path)
+	m, ok := f.backend.(Mover)
+	if !ok {
+		return &os.PathError{Op: "move", Path: f.Name(), Err: errors.ErrUnsupported}
+	}
+
+	return m.Move(path)
 }
 
-// Remove removes the temporary file.
+// Remove removes the backend's underlying storage, if it implements Remover (as NewDiskBackend does). Backends
+// with nothing to delete, such as MemoryBackend, report errors.ErrUnsupported.
 func (f *File) Remove() error {
 	if err := f.Close(); err != nil {
 		if pErr, ok := err.(*os.PathError); !ok || pErr.Err != os.ErrClosed {
@@ -228,5 +334,10 @@ func (f *File) Remove() error {
 		}
 	}
 
-	return os.Remove(f.file.Name())
+	r, ok := f.backend.(Remover)
+	if !ok {
+		return &os.PathError{Op: "remove", Path: f.Name(), Err: errors.ErrUnsupported}
+	}
+
+	return r.Remove()
 }
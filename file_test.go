@@ -2,6 +2,7 @@ package seekstream
 
 import (
 	"bytes"
+	"context"
 	"crypto/rand"
 	"errors"
 	"io"
@@ -10,6 +11,7 @@ import (
 	"reflect"
 	"testing"
 	"testing/iotest"
+	"time"
 )
 
 func TestNewFile(t *testing.T) {
@@ -25,15 +27,17 @@ func TestNewFile(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			f, err := NewFile(test.tempDir...)
+			backend, err := NewDiskBackend(test.tempDir...)
 			if (err != nil) != test.wantErr {
-				t.Fatalf("NewFile() error = %v, wantErr %v", err, test.wantErr)
+				t.Fatalf("NewDiskBackend() error = %v, wantErr %v", err, test.wantErr)
 			}
 
 			if test.wantErr {
 				return
 			}
 
+			f := NewFile(backend)
+
 			wantErr := &os.PathError{Op: "readat", Path: f.Name(), Err: errors.New("negative offset")}
 			if _, err = f.ReadAt([]byte{0}, -1); !reflect.DeepEqual(err, wantErr) {
 				t.Errorf("File.ReadAt() error = %v, want %v", err, wantErr)
@@ -80,10 +84,11 @@ func TestFile_ReadFrom(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			f, err := NewFile()
+			backend, err := NewDiskBackend()
 			if err != nil {
-				t.Fatalf("NewFile() error = %v", err)
+				t.Fatalf("NewDiskBackend() error = %v", err)
 			}
+			f := NewFile(backend)
 
 			defer func() {
 				if err = f.Remove(); err != nil {
@@ -152,10 +157,11 @@ func prepareFiles(t *testing.T) (*os.File, *File) {
 		t.Fatalf("os.File.Seek() error = %v", err)
 	}
 
-	f, err := NewFile()
+	backend, err := NewDiskBackend()
 	if err != nil {
-		t.Fatalf("NewFile() error = %v", err)
+		t.Fatalf("NewDiskBackend() error = %v", err)
 	}
+	f := NewFile(backend)
 
 	go func() {
 		if _, err := f.ReadFrom(tempFile); err != nil {
@@ -278,7 +284,7 @@ func TestFile_Move(t *testing.T) {
 		t.Errorf("File.Remove() error = %v, wantErr %v", err, true)
 	}
 
-	if err = os.Rename(f.file.Name()+"_", f.file.Name()); err != nil {
+	if err = os.Rename(f.backend.Name()+"_", f.backend.Name()); err != nil {
 		t.Errorf("os.Rename() error = %v", err)
 	}
 
@@ -306,8 +312,9 @@ func TestFile_Move(t *testing.T) {
 func TestFile_nil(t *testing.T) {
 	var (
 		f = &File{
-			done: make(chan struct{}),
-			wait: make(chan struct{}),
+			backend: diskBackend{(*os.File)(nil)},
+			done:    make(chan struct{}),
+			wait:    make(chan struct{}),
 		}
 		n   int64
 		err error
@@ -325,3 +332,135 @@ func TestFile_nil(t *testing.T) {
 		t.Errorf("File.Remove() error = %v, wantErr %v", err, os.ErrInvalid)
 	}
 }
+
+func newTestFile(t *testing.T) *File {
+	t.Helper()
+
+	backend, err := NewDiskBackend()
+	if err != nil {
+		t.Fatalf("NewDiskBackend() error = %v", err)
+	}
+
+	return NewFile(backend)
+}
+
+func TestFile_ReadContext_Cancel(t *testing.T) {
+	f := newTestFile(t)
+	defer func() {
+		if err := f.Remove(); err != nil {
+			t.Errorf("File.Remove() error = %v", err)
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	wantErr := &os.PathError{Op: "read", Path: f.Name(), Err: context.Canceled}
+	if _, err := f.ReadContext(ctx, make([]byte, 1)); !reflect.DeepEqual(err, wantErr) {
+		t.Errorf("File.ReadContext() error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestFile_ReadAtContext_Cancel(t *testing.T) {
+	f := newTestFile(t)
+	defer func() {
+		if err := f.Remove(); err != nil {
+			t.Errorf("File.Remove() error = %v", err)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	wantErr := &os.PathError{Op: "readat", Path: f.Name(), Err: context.DeadlineExceeded}
+	if _, err := f.ReadAtContext(ctx, make([]byte, 1), 0); !reflect.DeepEqual(err, wantErr) {
+		t.Errorf("File.ReadAtContext() error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestFile_SeekContext_Cancel(t *testing.T) {
+	f := newTestFile(t)
+	defer func() {
+		if err := f.Remove(); err != nil {
+			t.Errorf("File.Remove() error = %v", err)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	wantErr := &os.PathError{Op: "seek", Path: f.Name(), Err: context.DeadlineExceeded}
+	if _, err := f.SeekContext(ctx, 0, io.SeekEnd); !reflect.DeepEqual(err, wantErr) {
+		t.Errorf("File.SeekContext() error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestFile_SetDeadline(t *testing.T) {
+	f := newTestFile(t)
+	defer func() {
+		if err := f.Remove(); err != nil {
+			t.Errorf("File.Remove() error = %v", err)
+		}
+	}()
+
+	f.SetDeadline(time.Now().Add(10 * time.Millisecond))
+
+	wantErr := &os.PathError{Op: "read", Path: f.Name(), Err: os.ErrDeadlineExceeded}
+	if _, err := f.Read(make([]byte, 1)); !reflect.DeepEqual(err, wantErr) {
+		t.Errorf("File.Read() error = %v, want %v", err, wantErr)
+	}
+
+	f.SetDeadline(time.Time{})
+
+	done := make(chan struct{})
+	go func() {
+		if _, err := f.Write([]byte{1}); err != nil {
+			t.Errorf("File.Write() error = %v", err)
+		}
+		f.DoneWriting()
+	}()
+
+	go func() {
+		<-f.done
+		close(done)
+	}()
+
+	if n, err := f.Read(make([]byte, 1)); err != nil || n != 1 {
+		t.Errorf("File.Read() = (%v, %v), want (%v, %v)", n, err, 1, error(nil))
+	}
+
+	<-done
+}
+
+func TestFile_SetDeadlineWakesPendingRead(t *testing.T) {
+	f := newTestFile(t)
+	defer func() {
+		if err := f.Remove(); err != nil {
+			t.Errorf("File.Remove() error = %v", err)
+		}
+	}()
+
+	readErr := make(chan error, 1)
+	go func() {
+		_, err := f.Read(make([]byte, 1))
+		readErr <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+
+	f.SetDeadline(time.Now())
+
+	wantErr := &os.PathError{Op: "read", Path: f.Name(), Err: os.ErrDeadlineExceeded}
+	select {
+	case err := <-readErr:
+		if !reflect.DeepEqual(err, wantErr) {
+			t.Errorf("File.Read() error = %v, want %v", err, wantErr)
+		}
+
+	case <-time.After(time.Second):
+		t.Fatal("File.Read() did not return after SetDeadline expired a pending read")
+	}
+}
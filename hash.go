@@ -0,0 +1,48 @@
+package seekstream
+
+import (
+	"bytes"
+	"errors"
+	"hash"
+	"os"
+)
+
+// NewFileWithHash creates an empty File backed by backend whose successful Writes are also fed into h (e.g.
+// sha256.New() or crc32.NewIEEE()), so the stream's digest is available as soon as writing finishes, with no
+// separate pass over the data. Use Sum or VerifyAgainst once the File is done writing.
+func NewFileWithHash(backend Backend, h hash.Hash) *File {
+	f := NewFile(backend)
+	f.hash = h
+
+	return f
+}
+
+// Sum blocks until the File is closed for writing and returns its digest, or nil if the File wasn't created with
+// NewFileWithHash.
+func (f *File) Sum() []byte {
+	f.Wait()
+
+	if f.hash == nil {
+		return nil
+	}
+
+	f.hashMu.Lock()
+	defer f.hashMu.Unlock()
+
+	return f.hash.Sum(nil)
+}
+
+// VerifyAgainst blocks until the File is closed for writing and compares its digest against expected, returning
+// an error if they don't match or if the File wasn't created with NewFileWithHash.
+func (f *File) VerifyAgainst(expected []byte) error {
+	got := f.Sum()
+	if got == nil {
+		return &os.PathError{Op: "verify", Path: f.Name(), Err: errors.ErrUnsupported}
+	}
+
+	if !bytes.Equal(got, expected) {
+		return &os.PathError{Op: "verify", Path: f.Name(), Err: errors.New("checksum mismatch")}
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package seekstream
+
+import (
+	"crypto/sha256"
+	"errors"
+	"os"
+	"testing"
+)
+
+func newTestFileWithHash(t *testing.T) *File {
+	t.Helper()
+
+	backend, err := NewDiskBackend()
+	if err != nil {
+		t.Fatalf("NewDiskBackend() error = %v", err)
+	}
+
+	return NewFileWithHash(backend, sha256.New())
+}
+
+func TestFile_Sum(t *testing.T) {
+	f := newTestFileWithHash(t)
+	defer func() {
+		if err := f.Remove(); err != nil {
+			t.Errorf("File.Remove() error = %v", err)
+		}
+	}()
+
+	data := []byte("hello, seekstream")
+	if _, err := f.Write(data); err != nil {
+		t.Fatalf("File.Write() error = %v", err)
+	}
+	f.DoneWriting()
+
+	want := sha256.Sum256(data)
+	if got := f.Sum(); string(got) != string(want[:]) {
+		t.Errorf("File.Sum() = %x, want %x", got, want)
+	}
+}
+
+func TestFile_Sum_NoHash(t *testing.T) {
+	f := newTestFile(t)
+	defer func() {
+		if err := f.Remove(); err != nil {
+			t.Errorf("File.Remove() error = %v", err)
+		}
+	}()
+
+	f.DoneWriting()
+
+	if got := f.Sum(); got != nil {
+		t.Errorf("File.Sum() = %x, want nil", got)
+	}
+}
+
+func TestFile_VerifyAgainst(t *testing.T) {
+	f := newTestFileWithHash(t)
+	defer func() {
+		if err := f.Remove(); err != nil {
+			t.Errorf("File.Remove() error = %v", err)
+		}
+	}()
+
+	data := []byte("hello, seekstream")
+	if _, err := f.Write(data); err != nil {
+		t.Fatalf("File.Write() error = %v", err)
+	}
+	f.DoneWriting()
+
+	want := sha256.Sum256(data)
+	if err := f.VerifyAgainst(want[:]); err != nil {
+		t.Errorf("File.VerifyAgainst() error = %v, want nil", err)
+	}
+
+	bad := sha256.Sum256([]byte("tampered"))
+	wantErr := &os.PathError{Op: "verify", Path: f.Name(), Err: errors.New("checksum mismatch")}
+	if err := f.VerifyAgainst(bad[:]); err == nil || err.Error() != wantErr.Error() {
+		t.Errorf("File.VerifyAgainst() error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestFile_VerifyAgainst_NoHash(t *testing.T) {
+	f := newTestFile(t)
+	defer func() {
+		if err := f.Remove(); err != nil {
+			t.Errorf("File.Remove() error = %v", err)
+		}
+	}()
+
+	f.DoneWriting()
+
+	if err := f.VerifyAgainst(nil); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("File.VerifyAgainst() error = %v, want %v", err, errors.ErrUnsupported)
+	}
+}
@@ -0,0 +1,99 @@
+package seekstream
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+)
+
+// Reader is an independent, seekable view over a File's data, created with File.NewReader. Unlike reading from the
+// File directly, multiple Readers over the same File can be used concurrently, since each keeps its own offset and
+// reads through File.ReadAt rather than mutating any shared state.
+type Reader struct {
+	file *File
+	off  int64
+}
+
+// NewReader returns a Reader over f with its own, independent offset. This lets multiple goroutines iterate the
+// stream concurrently (e.g. serving the same in-progress download to several HTTP range requests), which File's
+// own Read and Seek cannot do since they share a single offset.
+func (f *File) NewReader() *Reader {
+	return &Reader{file: f}
+}
+
+// Read implements io.Reader, blocking like File.ReadAt until the buffer is filled or the EOF is reached.
+func (r *Reader) Read(p []byte) (int, error) {
+	n, err := r.file.ReadAt(p, r.off)
+	r.off += int64(n)
+	return n, err
+}
+
+// ReadContext is Read with a ctx that can cancel a pending block, and a deadline set with File.SetDeadline that
+// can expire it; see File.ReadAtContext.
+func (r *Reader) ReadContext(ctx context.Context, p []byte) (int, error) {
+	n, err := r.file.ReadAtContext(ctx, p, r.off)
+	r.off += int64(n)
+	return n, err
+}
+
+// Seek implements io.Seeker, blocking until the file size is known if whence is io.SeekEnd.
+func (r *Reader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+
+	case io.SeekCurrent:
+		offset += r.off
+
+	case io.SeekEnd:
+		offset += r.file.Size()
+
+	default:
+		return r.off, &os.PathError{Op: "seek", Path: r.file.Name(), Err: errors.New("invalid whence")}
+	}
+
+	if offset < 0 {
+		return r.off, &os.PathError{Op: "seek", Path: r.file.Name(), Err: errors.New("negative position")}
+	}
+
+	r.off = offset
+
+	return r.off, nil
+}
+
+// Close implements io.Closer. It does not close the underlying File, since the writer or other Readers may still
+// be using it.
+func (r *Reader) Close() error {
+	return nil
+}
+
+// WriteTo implements io.WriterTo, so io.Copy(dst, r) reads in bufSize-sized ReadAt calls instead of allocating and
+// driving its own copy buffer.
+func (r *Reader) WriteTo(w io.Writer) (int64, error) {
+	buf := make([]byte, bufSize)
+
+	var written int64
+
+	for {
+		nr, er := r.Read(buf)
+		if nr > 0 {
+			nw, ew := w.Write(buf[:nr])
+			written += int64(nw)
+			if ew != nil {
+				return written, ew
+			}
+
+			if nr != nw {
+				return written, io.ErrShortWrite
+			}
+		}
+
+		if er != nil {
+			if er == io.EOF {
+				er = nil
+			}
+
+			return written, er
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package seekstream
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"sync"
+	"testing"
+)
+
+func TestFile_NewReader_Independent(t *testing.T) {
+	f := newTestFile(t)
+	defer func() {
+		if err := f.Remove(); err != nil {
+			t.Errorf("File.Remove() error = %v", err)
+		}
+	}()
+
+	want := make([]byte, 1<<16)
+	if _, err := rand.Read(want); err != nil {
+		t.Fatalf("rand.Read() error = %v", err)
+	}
+
+	if _, err := f.Write(want); err != nil {
+		t.Fatalf("File.Write() error = %v", err)
+	}
+	f.DoneWriting()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			r := f.NewReader()
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Errorf("io.ReadAll() error = %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("io.ReadAll() != data written")
+			}
+			if err = r.Close(); err != nil {
+				t.Errorf("Reader.Close() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestReader_Seek(t *testing.T) {
+	f := newTestFile(t)
+	defer func() {
+		if err := f.Remove(); err != nil {
+			t.Errorf("File.Remove() error = %v", err)
+		}
+	}()
+
+	if _, err := f.Write([]byte("0123456789")); err != nil {
+		t.Fatalf("File.Write() error = %v", err)
+	}
+	f.DoneWriting()
+
+	r := f.NewReader()
+
+	if n, err := r.Seek(5, io.SeekStart); err != nil || n != 5 {
+		t.Fatalf("Reader.Seek() = (%v, %v), want (%v, %v)", n, err, 5, error(nil))
+	}
+
+	got := make([]byte, 3)
+	if _, err := io.ReadFull(r, got); err != nil {
+		t.Fatalf("Reader.Read() error = %v", err)
+	}
+	if string(got) != "567" {
+		t.Errorf("Reader.Read() = %q, want %q", got, "567")
+	}
+
+	if n, err := r.Seek(-1, io.SeekCurrent); err != nil || n != 7 {
+		t.Fatalf("Reader.Seek() = (%v, %v), want (%v, %v)", n, err, 7, error(nil))
+	}
+
+	if n, err := r.Seek(0, io.SeekEnd); err != nil || n != 10 {
+		t.Fatalf("Reader.Seek() = (%v, %v), want (%v, %v)", n, err, 10, error(nil))
+	}
+
+	if _, err := r.Seek(-20, io.SeekStart); err == nil {
+		t.Errorf("Reader.Seek() error = %v, want non-nil", err)
+	}
+
+	if _, err := r.Seek(0, -1); err == nil {
+		t.Errorf("Reader.Seek() error = %v, want non-nil", err)
+	}
+}
+
+func TestReader_WriteTo(t *testing.T) {
+	f := newTestFile(t)
+	defer func() {
+		if err := f.Remove(); err != nil {
+			t.Errorf("File.Remove() error = %v", err)
+		}
+	}()
+
+	want := make([]byte, bufSize*2+1<<10)
+	if _, err := rand.Read(want); err != nil {
+		t.Fatalf("rand.Read() error = %v", err)
+	}
+
+	if _, err := f.Write(want); err != nil {
+		t.Fatalf("File.Write() error = %v", err)
+	}
+	f.DoneWriting()
+
+	buf := new(bytes.Buffer)
+	n, err := io.Copy(buf, f.NewReader())
+	if err != nil {
+		t.Fatalf("io.Copy() error = %v", err)
+	}
+	if n != int64(len(want)) {
+		t.Errorf("io.Copy() = %v, want %v", n, len(want))
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("io.Copy() != data written")
+	}
+}
@@ -0,0 +1,97 @@
+package streamfs
+
+import (
+	"io"
+	"io/fs"
+	"time"
+
+	"github.com/zRedShift/seekstream"
+)
+
+// streamFile is the fs.File returned by FS.Open. It embeds a seekstream.Reader for its read cursor, so the same
+// stream can be opened multiple times concurrently.
+type streamFile struct {
+	name string
+	file *seekstream.File
+	*seekstream.Reader
+}
+
+// Stat implements fs.File. The reported size is the number of bytes committed so far; it does not block. Call
+// FinalStat instead to block until the stream is done and get its final, immutable size.
+func (sf *streamFile) Stat() (fs.FileInfo, error) {
+	return fileInfo{name: sf.name, size: sf.file.Written()}, nil
+}
+
+// FinalStat blocks until the underlying File is closed for writing (see seekstream.File.Wait) and returns its
+// final size.
+func (sf *streamFile) FinalStat() (fs.FileInfo, error) {
+	return fileInfo{name: sf.name, size: sf.file.Size()}, nil
+}
+
+// fileInfo is the fs.FileInfo returned for streamFile entries.
+type fileInfo struct {
+	name string
+	size int64
+}
+
+func (fi fileInfo) Name() string       { return fi.name }
+func (fi fileInfo) Size() int64        { return fi.size }
+func (fi fileInfo) Mode() fs.FileMode  { return 0444 }
+func (fi fileInfo) ModTime() time.Time { return time.Time{} }
+func (fi fileInfo) IsDir() bool        { return false }
+func (fi fileInfo) Sys() interface{}   { return nil }
+
+// dirFile is the fs.ReadDirFile returned for the FS root, ".". FS has no subdirectories, so it's the only
+// directory that can be opened.
+type dirFile struct {
+	entries []fs.DirEntry
+	off     int
+}
+
+// Stat implements fs.File.
+func (d *dirFile) Stat() (fs.FileInfo, error) { return dirInfo{}, nil }
+
+// Read implements fs.File. Reading a directory's bytes is invalid, matching os.File's behavior.
+func (d *dirFile) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: ".", Err: fs.ErrInvalid}
+}
+
+// Close implements fs.File.
+func (d *dirFile) Close() error { return nil }
+
+// ReadDir implements fs.ReadDirFile, following the same pagination convention as os.File.ReadDir: n <= 0 returns
+// all remaining entries with a nil error, n > 0 returns at most n and io.EOF once exhausted.
+func (d *dirFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	remaining := len(d.entries) - d.off
+
+	if n <= 0 {
+		entries := d.entries[d.off:]
+		d.off = len(d.entries)
+
+		return entries, nil
+	}
+
+	if n > remaining {
+		n = remaining
+	}
+
+	entries := d.entries[d.off : d.off+n]
+	d.off += n
+
+	var err error
+	if n == 0 {
+		err = io.EOF
+	}
+
+	return entries, err
+}
+
+// dirInfo is the fs.FileInfo for the FS root.
+type dirInfo struct{}
+
+func (dirInfo) Name() string       { return "." }
+func (dirInfo) Size() int64        { return 0 }
+func (dirInfo) Mode() fs.FileMode  { return fs.ModeDir | 0555 }
+func (dirInfo) ModTime() time.Time { return time.Time{} }
+func (dirInfo) IsDir() bool        { return true }
+func (dirInfo) Sys() interface{}   { return nil }
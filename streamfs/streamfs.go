@@ -0,0 +1,113 @@
+// Package streamfs adapts one or more in-progress seekstream.Files to the io/fs.FS family, so a growing stream can
+// be plugged into APIs such as http.FileServerFS, template.ParseFS, or archive/zip.NewReader while it's still being
+// written.
+package streamfs
+
+import (
+	"io/fs"
+	"path"
+	"sort"
+	"sync"
+
+	"github.com/zRedShift/seekstream"
+)
+
+// FS is an io/fs.FS, fs.ReadDirFS and fs.StatFS backed by a flat, named set of seekstream.Files. It has no concept
+// of subdirectories: every registered stream is a direct entry of the root.
+type FS struct {
+	mu      sync.RWMutex
+	streams map[string]*seekstream.File
+}
+
+// New creates an empty FS. Use AddStream to register the streams it should serve.
+func New() *FS {
+	return &FS{streams: make(map[string]*seekstream.File)}
+}
+
+// AddStream registers f under name, making it available as a file at that path within the FS. A later call with
+// the same name replaces the previous registration.
+func (fsys *FS) AddStream(name string, f *seekstream.File) {
+	fsys.mu.Lock()
+	defer fsys.mu.Unlock()
+
+	fsys.streams[name] = f
+}
+
+// RemoveStream unregisters the stream at name, if any.
+func (fsys *FS) RemoveStream(name string) {
+	fsys.mu.Lock()
+	defer fsys.mu.Unlock()
+
+	delete(fsys.streams, name)
+}
+
+func (fsys *FS) lookup(op, name string) (*seekstream.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+
+	fsys.mu.RLock()
+	defer fsys.mu.RUnlock()
+
+	f, ok := fsys.streams[name]
+	if !ok {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+
+	return f, nil
+}
+
+// Open implements fs.FS. Opening "." returns the root directory listing; opening a registered stream's name
+// returns an fs.File with its own read cursor, independent of any other handle on the same stream, so the same
+// in-progress download can be served to multiple concurrent callers.
+func (fsys *FS) Open(name string) (fs.File, error) {
+	if name == "." {
+		entries, err := fsys.ReadDir(".")
+		if err != nil {
+			return nil, err
+		}
+
+		return &dirFile{entries: entries}, nil
+	}
+
+	f, err := fsys.lookup("open", name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &streamFile{name: path.Base(name), file: f, Reader: f.NewReader()}, nil
+}
+
+// Stat implements fs.StatFS. The returned size is the number of bytes committed so far; it does not block. See
+// streamFile.FinalStat for a variant that blocks for the final size.
+func (fsys *FS) Stat(name string) (fs.FileInfo, error) {
+	if name == "." {
+		return dirInfo{}, nil
+	}
+
+	f, err := fsys.lookup("stat", name)
+	if err != nil {
+		return nil, err
+	}
+
+	return fileInfo{name: path.Base(name), size: f.Written()}, nil
+}
+
+// ReadDir implements fs.ReadDirFS. FS is a flat namespace, so only "." can be listed; it returns one entry per
+// registered stream.
+func (fsys *FS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if name != "." {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+
+	fsys.mu.RLock()
+	entries := make([]fs.DirEntry, 0, len(fsys.streams))
+	for n, f := range fsys.streams {
+		entries = append(entries, fs.FileInfoToDirEntry(fileInfo{name: n, size: f.Written()}))
+	}
+	fsys.mu.RUnlock()
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	return entries, nil
+}
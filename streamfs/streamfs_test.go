@@ -0,0 +1,217 @@
+package streamfs
+
+import (
+	"io"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/zRedShift/seekstream"
+)
+
+func newFile(t *testing.T) *seekstream.File {
+	t.Helper()
+
+	backend, err := seekstream.NewDiskBackend()
+	if err != nil {
+		t.Fatalf("NewDiskBackend() error = %v", err)
+	}
+
+	return seekstream.NewFile(backend)
+}
+
+func TestFS_OpenRead(t *testing.T) {
+	f := newFile(t)
+	defer func() {
+		if err := f.Remove(); err != nil {
+			t.Errorf("File.Remove() error = %v", err)
+		}
+	}()
+
+	if _, err := f.Write([]byte("hello world")); err != nil {
+		t.Fatalf("File.Write() error = %v", err)
+	}
+	f.DoneWriting()
+
+	fsys := New()
+	fsys.AddStream("hello.txt", f)
+
+	got, err := fs.ReadFile(fsys, "hello.txt")
+	if err != nil {
+		t.Fatalf("fs.ReadFile() error = %v", err)
+	}
+
+	if string(got) != "hello world" {
+		t.Errorf("fs.ReadFile() = %q, want %q", got, "hello world")
+	}
+
+	if _, err = fsys.Open("missing.txt"); !fs.ValidPath("missing.txt") || err == nil {
+		t.Errorf("FS.Open() error = %v, want non-nil", err)
+	}
+
+	fsys.RemoveStream("hello.txt")
+	if _, err = fsys.Open("hello.txt"); err == nil {
+		t.Errorf("FS.Open() error = %v, want non-nil", err)
+	}
+}
+
+func TestFS_ConcurrentOpens(t *testing.T) {
+	f := newFile(t)
+	defer func() {
+		if err := f.Remove(); err != nil {
+			t.Errorf("File.Remove() error = %v", err)
+		}
+	}()
+
+	if _, err := f.Write([]byte("0123456789")); err != nil {
+		t.Fatalf("File.Write() error = %v", err)
+	}
+	f.DoneWriting()
+
+	fsys := New()
+	fsys.AddStream("data", f)
+
+	a, err := fsys.Open("data")
+	if err != nil {
+		t.Fatalf("FS.Open() error = %v", err)
+	}
+	b, err := fsys.Open("data")
+	if err != nil {
+		t.Fatalf("FS.Open() error = %v", err)
+	}
+
+	bufA := make([]byte, 4)
+	if _, err = io.ReadFull(a, bufA); err != nil {
+		t.Fatalf("a.Read() error = %v", err)
+	}
+
+	bufB := make([]byte, 10)
+	if _, err = io.ReadFull(b, bufB); err != nil {
+		t.Fatalf("b.Read() error = %v", err)
+	}
+
+	if string(bufA) != "0123" {
+		t.Errorf("a.Read() = %q, want %q", bufA, "0123")
+	}
+
+	if string(bufB) != "0123456789" {
+		t.Errorf("b.Read() = %q, want %q", bufB, "0123456789")
+	}
+}
+
+func TestFS_StatAndFinalStat(t *testing.T) {
+	f := newFile(t)
+	defer func() {
+		if err := f.Remove(); err != nil {
+			t.Errorf("File.Remove() error = %v", err)
+		}
+	}()
+
+	fsys := New()
+	fsys.AddStream("data", f)
+
+	if _, err := f.Write([]byte("12345")); err != nil {
+		t.Fatalf("File.Write() error = %v", err)
+	}
+
+	fi, err := fsys.Stat("data")
+	if err != nil {
+		t.Fatalf("FS.Stat() error = %v", err)
+	}
+	if fi.Size() != 5 {
+		t.Errorf("FS.Stat().Size() = %v, want %v", fi.Size(), 5)
+	}
+
+	opened, err := fsys.Open("data")
+	if err != nil {
+		t.Fatalf("FS.Open() error = %v", err)
+	}
+	sf := opened.(*streamFile)
+
+	final := make(chan int64, 1)
+	go func() {
+		fi, err := sf.FinalStat()
+		if err != nil {
+			t.Errorf("streamFile.FinalStat() error = %v", err)
+		}
+		final <- fi.Size()
+	}()
+
+	if _, err = f.Write([]byte("6789")); err != nil {
+		t.Fatalf("File.Write() error = %v", err)
+	}
+	f.DoneWriting()
+
+	if got := <-final; got != 9 {
+		t.Errorf("streamFile.FinalStat().Size() = %v, want %v", got, 9)
+	}
+}
+
+func TestFS_StatRoot(t *testing.T) {
+	fsys := New()
+
+	fi, err := fsys.Stat(".")
+	if err != nil {
+		t.Fatalf("FS.Stat() error = %v", err)
+	}
+
+	if !fi.IsDir() {
+		t.Errorf("FS.Stat(\".\").IsDir() = %v, want %v", fi.IsDir(), true)
+	}
+
+	if _, err = fs.Stat(fsys, "."); err != nil {
+		t.Errorf("fs.Stat() error = %v", err)
+	}
+}
+
+func TestFS_ReadDir(t *testing.T) {
+	f1, f2 := newFile(t), newFile(t)
+	f1.DoneWriting()
+	f2.DoneWriting()
+	defer func() {
+		if err := f1.Remove(); err != nil {
+			t.Errorf("File.Remove() error = %v", err)
+		}
+		if err := f2.Remove(); err != nil {
+			t.Errorf("File.Remove() error = %v", err)
+		}
+	}()
+
+	fsys := New()
+	fsys.AddStream("a.txt", f1)
+	fsys.AddStream("b.txt", f2)
+
+	entries, err := fsys.ReadDir(".")
+	if err != nil {
+		t.Fatalf("FS.ReadDir() error = %v", err)
+	}
+
+	if len(entries) != 2 || entries[0].Name() != "a.txt" || entries[1].Name() != "b.txt" {
+		t.Errorf("FS.ReadDir() = %v, want [a.txt b.txt]", entries)
+	}
+
+	if _, err = fsys.ReadDir("a.txt"); err == nil {
+		t.Errorf("FS.ReadDir() error = %v, want non-nil", err)
+	}
+}
+
+func TestFS_TestFS(t *testing.T) {
+	f := newFile(t)
+	defer func() {
+		if err := f.Remove(); err != nil {
+			t.Errorf("File.Remove() error = %v", err)
+		}
+	}()
+
+	if _, err := f.Write([]byte("contents")); err != nil {
+		t.Fatalf("File.Write() error = %v", err)
+	}
+	f.DoneWriting()
+
+	fsys := New()
+	fsys.AddStream("file.txt", f)
+
+	if err := fstest.TestFS(fsys, "file.txt"); err != nil {
+		t.Errorf("fstest.TestFS() error = %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+//go:build linux || darwin
+
+package streamfuse
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"syscall"
+
+	"bazil.org/fuse"
+	"bazil.org/fuse/fs"
+
+	"github.com/zRedShift/seekstream"
+)
+
+// root is the fs.FS and the fs.Node for the mount's top-level directory. It has no concept of subdirectories:
+// every registered stream is a direct entry of the root.
+type root struct {
+	mu    sync.RWMutex
+	nodes map[string]*node
+}
+
+func (r *root) Root() (fs.Node, error) { return r, nil }
+
+func (r *root) Attr(ctx context.Context, a *fuse.Attr) error {
+	a.Mode = os.ModeDir | 0o555
+	return nil
+}
+
+func (r *root) Lookup(ctx context.Context, name string) (fs.Node, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	n, ok := r.nodes[name]
+	if !ok {
+		return nil, syscall.ENOENT
+	}
+
+	return n, nil
+}
+
+func (r *root) ReadDirAll(ctx context.Context) ([]fuse.Dirent, error) {
+	r.mu.RLock()
+	entries := make([]fuse.Dirent, 0, len(r.nodes))
+	for name := range r.nodes {
+		entries = append(entries, fuse.Dirent{Name: name, Type: fuse.DT_File})
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+
+	return entries, nil
+}
+
+// node is the fs.Node and fs.Handle for a single registered stream.
+type node struct {
+	name string
+	file *seekstream.File
+}
+
+// Attr reports the stream's currently committed size while it's still being written, and its final size once
+// the stream is done.
+func (n *node) Attr(ctx context.Context, a *fuse.Attr) error {
+	a.Mode = 0o444
+	if n.file.IsDone() {
+		a.Size = uint64(n.file.Size())
+	} else {
+		a.Size = uint64(n.file.Written())
+	}
+
+	return nil
+}
+
+// Read delegates to File.ReadAtContext, blocking until req.Size bytes from req.Offset have been written, the stream
+// is done writing, or ctx is canceled.
+func (n *node) Read(ctx context.Context, req *fuse.ReadRequest, resp *fuse.ReadResponse) error {
+	buf := make([]byte, req.Size)
+
+	nr, err := n.file.ReadAtContext(ctx, buf, req.Offset)
+	if err != nil && !errors.Is(err, io.EOF) {
+		if ctx.Err() != nil {
+			return syscall.EINTR
+		}
+		return err
+	}
+
+	resp.Data = buf[:nr]
+
+	return nil
+}
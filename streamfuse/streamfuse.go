@@ -0,0 +1,62 @@
+//go:build linux || darwin
+
+// Package streamfuse mounts a directory containing one or more in-progress seekstream.Files as a read-only FUSE
+// filesystem, so external processes -- media players, tail -f, archive tools -- can consume a growing stream as if
+// it were an ordinary file on disk.
+package streamfuse
+
+import (
+	"bazil.org/fuse"
+	"bazil.org/fuse/fs"
+
+	"github.com/zRedShift/seekstream"
+)
+
+// Server is a mounted streamfuse filesystem, returned by Mount. Call Unmount when done with it.
+type Server struct {
+	conn *fuse.Conn
+	dir  string
+	done <-chan struct{}
+	err  error
+}
+
+// Mount mounts a read-only FUSE filesystem at dir, serving each entry of files as a regular file under its map
+// key. The files may still be open for writing; reads past what's currently committed block until enough data is
+// written to fill them or the stream is closed, same as seekstream.File.ReadAt.
+func Mount(dir string, files map[string]*seekstream.File) (*Server, error) {
+	conn, err := fuse.Mount(dir, fuse.ReadOnly(), fuse.FSName("seekstream"), fuse.Subtype("streamfuse"))
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[string]*node, len(files))
+	for name, f := range files {
+		nodes[name] = &node{name: name, file: f}
+	}
+
+	done := make(chan struct{})
+	s := &Server{conn: conn, dir: dir, done: done}
+
+	go func() {
+		defer close(done)
+		s.err = fs.Serve(conn, &root{nodes: nodes})
+	}()
+
+	return s, nil
+}
+
+// Unmount unmounts the filesystem and waits for it to stop serving requests, returning any error fs.Serve
+// encountered.
+func (s *Server) Unmount() error {
+	if err := fuse.Unmount(s.dir); err != nil {
+		return err
+	}
+
+	<-s.done
+
+	if err := s.conn.Close(); err != nil {
+		return err
+	}
+
+	return s.err
+}
@@ -0,0 +1,145 @@
+//go:build linux || darwin
+
+package streamfuse
+
+import (
+	"context"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+
+	"bazil.org/fuse"
+
+	"github.com/zRedShift/seekstream"
+)
+
+func TestMount(t *testing.T) {
+	dir := t.TempDir()
+
+	backend := seekstream.NewMemoryBackend("hello.txt")
+	f := seekstream.NewFile(backend)
+	if _, err := f.Write([]byte("hello, fuse\n")); err != nil {
+		t.Fatalf("File.Write() error = %v", err)
+	}
+	f.DoneWriting()
+
+	s, err := Mount(dir, map[string]*seekstream.File{"hello.txt": f})
+	if err != nil {
+		t.Skipf("Mount() error = %v (FUSE not usable in this environment)", err)
+	}
+	defer func() {
+		if err := s.Unmount(); err != nil {
+			t.Errorf("Server.Unmount() error = %v", err)
+		}
+	}()
+
+	got, err := os.ReadFile(dir + "/hello.txt")
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(got) != "hello, fuse\n" {
+		t.Errorf("ReadFile() = %q, want %q", got, "hello, fuse\n")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "hello.txt" {
+		t.Errorf("ReadDir() = %v, want single entry %q", entries, "hello.txt")
+	}
+}
+
+// The remaining tests exercise root and node directly, since mounting a real FUSE filesystem isn't available in
+// every build environment (see TestMount's skip).
+
+func TestRoot_LookupAndReadDirAll(t *testing.T) {
+	f := seekstream.NewFile(seekstream.NewMemoryBackend("a.txt"))
+	r := &root{nodes: map[string]*node{"a.txt": {name: "a.txt", file: f}}}
+
+	if _, err := r.Lookup(context.Background(), "missing"); err != syscall.ENOENT {
+		t.Errorf("Lookup() error = %v, want %v", err, syscall.ENOENT)
+	}
+
+	n, err := r.Lookup(context.Background(), "a.txt")
+	if err != nil || n == nil {
+		t.Fatalf("Lookup() = (%v, %v), want a node", n, err)
+	}
+
+	entries, err := r.ReadDirAll(context.Background())
+	if err != nil {
+		t.Fatalf("ReadDirAll() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name != "a.txt" {
+		t.Errorf("ReadDirAll() = %v, want single entry %q", entries, "a.txt")
+	}
+}
+
+func TestNode_AttrSize(t *testing.T) {
+	f := seekstream.NewFile(seekstream.NewMemoryBackend("a.txt"))
+	n := &node{name: "a.txt", file: f}
+
+	if _, err := f.Write([]byte("12345")); err != nil {
+		t.Fatalf("File.Write() error = %v", err)
+	}
+
+	var a fuse.Attr
+	if err := n.Attr(context.Background(), &a); err != nil {
+		t.Fatalf("Attr() error = %v", err)
+	}
+	if a.Size != 5 {
+		t.Errorf("Attr().Size = %v, want %v (writing in progress)", a.Size, 5)
+	}
+
+	f.DoneWriting()
+
+	if err := n.Attr(context.Background(), &a); err != nil {
+		t.Fatalf("Attr() error = %v", err)
+	}
+	if a.Size != 5 {
+		t.Errorf("Attr().Size = %v, want %v (final)", a.Size, 5)
+	}
+}
+
+func TestNode_Read(t *testing.T) {
+	f := seekstream.NewFile(seekstream.NewMemoryBackend("a.txt"))
+	n := &node{name: "a.txt", file: f}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	resp := new(fuse.ReadResponse)
+	go func() {
+		defer wg.Done()
+
+		req := &fuse.ReadRequest{Offset: 0, Size: 5}
+		if err := n.Read(context.Background(), req, resp); err != nil {
+			t.Errorf("Read() error = %v", err)
+		}
+	}()
+
+	if _, err := f.Write([]byte("12345")); err != nil {
+		t.Fatalf("File.Write() error = %v", err)
+	}
+	f.DoneWriting()
+
+	wg.Wait()
+
+	if string(resp.Data) != "12345" {
+		t.Errorf("Read() resp.Data = %q, want %q", resp.Data, "12345")
+	}
+}
+
+func TestNode_Read_CancelContext(t *testing.T) {
+	f := seekstream.NewFile(seekstream.NewMemoryBackend("a.txt"))
+	n := &node{name: "a.txt", file: f}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req := &fuse.ReadRequest{Offset: 0, Size: 5}
+	if err := n.Read(ctx, req, new(fuse.ReadResponse)); err != syscall.EINTR {
+		t.Errorf("Read() error = %v, want %v", err, syscall.EINTR)
+	}
+}